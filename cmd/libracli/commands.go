@@ -0,0 +1,239 @@
+// Copyright (c) The Libra Core Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/libra/libra-client-sdk-go/jsonrpc"
+	"github.com/libra/libra-client-sdk-go/libraclient"
+)
+
+// printJSON pretty-prints v, the typed client result. Output for --raw goes
+// through printRaw instead.
+func printJSON(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// printRaw issues method with params directly against the configured server
+// and prints the server's JSON-RPC response object, for --raw callers who want
+// the server's own response body instead of the typed client result.
+func printRaw(method jsonrpc.Method, params ...jsonrpc.Param) error {
+	rpc := jsonrpc.NewClient(*url)
+	req := jsonrpc.NewRequest(method, params...)
+	resps, err := rpc.Call(req)
+	if err != nil {
+		return err
+	}
+	resp := resps[req.ID]
+	if resp.Error != nil {
+		return resp.Error
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+func getCurrencies(client libraclient.Client) error {
+	if *raw {
+		return printRaw(libraclient.GetCurrencies)
+	}
+	ret, err := client.GetCurrencies()
+	if err != nil {
+		return err
+	}
+	return printJSON(ret)
+}
+
+func getMetadata(client libraclient.Client, args []string) error {
+	fs := flag.NewFlagSet("get-metadata", flag.ExitOnError)
+	version := fs.Uint64("version", 0, "ledger version to query (0 = latest)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *version == 0 {
+		if *raw {
+			return printRaw(libraclient.GetMetadata)
+		}
+		ret, err := client.GetMetadata()
+		if err != nil {
+			return err
+		}
+		return printJSON(ret)
+	}
+	if *raw {
+		return printRaw(libraclient.GetMetadata, *version)
+	}
+	ret, err := client.GetMetadataByVersion(*version)
+	if err != nil {
+		return err
+	}
+	return printJSON(ret)
+}
+
+func getAccount(client libraclient.Client, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: get-account <addr>")
+	}
+	address := libraclient.Address(args[0])
+	if *raw {
+		return printRaw(libraclient.GetAccount, address)
+	}
+	ret, err := client.GetAccount(address)
+	if err != nil {
+		return err
+	}
+	return printJSON(ret)
+}
+
+func getAccountTransaction(client libraclient.Client, args []string) error {
+	fs := flag.NewFlagSet("get-account-txn", flag.ExitOnError)
+	includeEvents := fs.Bool("events", false, "include transaction events")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	rest := fs.Args()
+	if len(rest) != 2 {
+		return fmt.Errorf("usage: get-account-txn [--events] <addr> <seq>")
+	}
+	address := libraclient.Address(rest[0])
+	seq, err := strconv.ParseUint(rest[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid sequence number %q: %w", rest[1], err)
+	}
+
+	if *raw {
+		return printRaw(libraclient.GetAccountTransaction, address, seq, *includeEvents)
+	}
+	ret, err := client.GetAccountTransaction(address, seq, *includeEvents)
+	if err != nil {
+		return err
+	}
+	return printJSON(ret)
+}
+
+func getAccountTransactions(client libraclient.Client, args []string) error {
+	if len(args) != 3 {
+		return fmt.Errorf("usage: get-account-txns <addr> <start> <limit>")
+	}
+	address := libraclient.Address(args[0])
+	start, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid start %q: %w", args[1], err)
+	}
+	limit, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid limit %q: %w", args[2], err)
+	}
+
+	if *raw {
+		return printRaw(libraclient.GetAccountTransactions, address, start, limit, true)
+	}
+	ret, err := client.GetAccountTransactions(address, start, limit, true)
+	if err != nil {
+		return err
+	}
+	return printJSON(ret)
+}
+
+func getTransactions(client libraclient.Client, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: get-txns <start> <limit>")
+	}
+	start, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid start %q: %w", args[0], err)
+	}
+	limit, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid limit %q: %w", args[1], err)
+	}
+
+	if *raw {
+		return printRaw(libraclient.GetTransactions, start, limit, true)
+	}
+	ret, err := client.GetTransactions(start, limit, true)
+	if err != nil {
+		return err
+	}
+	return printJSON(ret)
+}
+
+func getEvents(client libraclient.Client, args []string) error {
+	if len(args) != 3 {
+		return fmt.Errorf("usage: get-events <key> <start> <limit>")
+	}
+	key := args[0]
+	start, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid start %q: %w", args[1], err)
+	}
+	limit, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid limit %q: %w", args[2], err)
+	}
+
+	if *raw {
+		return printRaw(libraclient.GetEvents, key, start, limit)
+	}
+	ret, err := client.GetEvents(key, start, limit)
+	if err != nil {
+		return err
+	}
+	return printJSON(ret)
+}
+
+func submit(client libraclient.Client, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: submit <hex>")
+	}
+	if *raw {
+		return printRaw(libraclient.Submit, args[0])
+	}
+	if err := client.Submit(args[0]); err != nil {
+		return err
+	}
+	fmt.Println("ok")
+	return nil
+}
+
+func waitForTransaction(client libraclient.Client, args []string) error {
+	if len(args) != 5 {
+		return fmt.Errorf("usage: wait-for-txn <addr> <seq> <sig> <expTs> <timeoutSec>")
+	}
+	address := libraclient.Address(args[0])
+	seq, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid sequence number %q: %w", args[1], err)
+	}
+	signature := args[2]
+	expirationTimeSec, err := strconv.ParseUint(args[3], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid expiration time %q: %w", args[3], err)
+	}
+	timeoutSec, err := strconv.ParseUint(args[4], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timeout %q: %w", args[4], err)
+	}
+
+	ret, err := client.WaitForTransaction(
+		address, seq, signature, expirationTimeSec, time.Duration(timeoutSec)*time.Second)
+	if err != nil {
+		return err
+	}
+	return printJSON(ret)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) The Libra Core Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Command libracli is a thin command line wrapper around libraclient.Client,
+// for on-call debugging and quick manual testing against a Libra JSON-RPC
+// server.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/libra/libra-client-sdk-go/libraclient"
+	"github.com/libra/libra-client-sdk-go/testnet"
+)
+
+var (
+	url     = flag.String("url", testnet.URL, "Libra JSON-RPC server URL")
+	chainID = flag.Int("chain-id", int(testnet.ChainID), "Libra chain id")
+	raw     = flag.Bool("raw", false, "print the direct JSON-RPC response body instead of pretty JSON")
+)
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		usage()
+		os.Exit(1)
+	}
+
+	cmd, rest := args[0], args[1:]
+	if cmd == "mint" {
+		if err := mint(rest); err != nil {
+			fatal(err)
+		}
+		return
+	}
+
+	client := libraclient.New(byte(*chainID), *url)
+
+	var err error
+	switch cmd {
+	case "get-currencies":
+		err = getCurrencies(client)
+	case "get-metadata":
+		err = getMetadata(client, rest)
+	case "get-account":
+		err = getAccount(client, rest)
+	case "get-account-txn":
+		err = getAccountTransaction(client, rest)
+	case "get-account-txns":
+		err = getAccountTransactions(client, rest)
+	case "get-txns":
+		err = getTransactions(client, rest)
+	case "get-events":
+		err = getEvents(client, rest)
+	case "submit":
+		err = submit(client, rest)
+	case "wait-for-txn":
+		err = waitForTransaction(client, rest)
+	case "send":
+		err = send(client, rest)
+	default:
+		usage()
+		os.Exit(1)
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "libracli:", err)
+	os.Exit(1)
+}
+
+func usage() {
+	fmt.Fprint(os.Stderr, `libracli is a command line tool for the Libra JSON-RPC API, built on libraclient.Client.
+
+Usage:
+
+	libracli [--url URL] [--chain-id ID] [--raw] <command> [arguments]
+
+Commands:
+
+	get-currencies
+	get-metadata [--version N]
+	get-account <addr>
+	get-account-txn [--events] <addr> <seq>
+	get-account-txns <addr> <start> <limit>
+	get-txns <start> <limit>
+	get-events <key> <start> <limit>
+	submit <hex>
+	wait-for-txn <addr> <seq> <sig> <expTs> <timeoutSec>
+	mint <authKeyHex> <amount> <currencyCode>
+	send <amount> <currencyCode>
+
+Global flags:
+
+`)
+	flag.PrintDefaults()
+}
@@ -0,0 +1,27 @@
+// Copyright (c) The Libra Core Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/libra/libra-client-sdk-go/testnet"
+)
+
+func mint(args []string) error {
+	if len(args) != 3 {
+		return fmt.Errorf("usage: mint <authKeyHex> <amount> <currencyCode>")
+	}
+	authKeyHex := args[0]
+	amount, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid amount %q: %w", args[1], err)
+	}
+	currencyCode := args[2]
+
+	seq := testnet.MustMint(authKeyHex, amount, currencyCode)
+	fmt.Println(seq)
+	return nil
+}
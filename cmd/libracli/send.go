@@ -0,0 +1,96 @@
+// Copyright (c) The Libra Core Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/libra/libra-client-sdk-go/libraclient"
+	"github.com/libra/libra-client-sdk-go/librakeys"
+	"github.com/libra/libra-client-sdk-go/librasigner"
+	"github.com/libra/libra-client-sdk-go/librastd"
+	"github.com/libra/libra-client-sdk-go/testnet"
+)
+
+// send mints two fresh testnet accounts and transfers amount of currencyCode
+// from one to the other, blocking until the transfer lands. It is the same
+// flow libraclient's "submit transaction" integration test performs, exposed
+// as an operator command for on-call debugging and quick manual testing.
+func send(client libraclient.Client, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: send <amount> <currency-code>")
+	}
+	amount, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid amount %q: %w", args[0], err)
+	}
+	currencyCode := args[1]
+
+	sender, err := genAccount(client, currencyCode, amount*2)
+	if err != nil {
+		return fmt.Errorf("sender: %w", err)
+	}
+	receiver, err := genAccount(client, currencyCode, 0)
+	if err != nil {
+		return fmt.Errorf("receiver: %w", err)
+	}
+
+	var sequenceNum uint64
+	script := librastd.EncodePeerToPeerScriptWithMetadata(
+		receiver.AccountAddress, currencyCode, amount, []byte{}, []byte{})
+	expiration := time.Now().Add(time.Second * 30)
+	txn := librasigner.Sign(
+		sender, sequenceNum, script,
+		1_000_000, 0, currencyCode,
+		expiration,
+		testnet.ChainID,
+	)
+	if err := client.Submit(txn.HexSignedTransaction()); err != nil {
+		return err
+	}
+
+	ret, err := client.WaitForTransaction(
+		libraclient.Address(hex.EncodeToString(sender.AccountAddress)),
+		sequenceNum,
+		txn.HexSignature(),
+		uint64(expiration.Unix()),
+		time.Second*30,
+	)
+	if err != nil {
+		return err
+	}
+	return printJSON(ret)
+}
+
+// genAccount creates a new key pair, mints amount of currencyCode into it, and
+// waits until the account reflects the mint before returning. It errors out if
+// the mint does not land before waitAccountSequence gives up, instead of
+// handing back an account that may not be funded yet.
+func genAccount(client libraclient.Client, currencyCode string, amount uint64) (*librakeys.Keys, error) {
+	keys := librakeys.MustGenKeys()
+	seq := testnet.MustMint(keys.AuthKey.ToString(), amount, currencyCode)
+	if err := waitAccountSequence(client, seq); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// waitAccountSequence waits for the mint to land by polling the sequence
+// number of the designated-dealer account testnet.MustMint minted from, not
+// the newly created account: a fresh account's own SequenceNumber stays 0
+// until it submits a transaction of its own, so it would never reach seq.
+func waitAccountSequence(client libraclient.Client, seq int) error {
+	const ddAddress = libraclient.Address("000000000000000000000000000000DD")
+	for i := 0; i < 100; i++ {
+		account, err := client.GetAccount(ddAddress)
+		if err == nil && account != nil && account.SequenceNumber >= uint64(seq) {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("waiting for mint to sequence %d timed out", seq)
+}
@@ -0,0 +1,130 @@
+// Copyright (c) The Libra Core Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jsonrpc implements the Libra JSON-RPC wire protocol used by libraclient.
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+)
+
+// Method is a Libra JSON-RPC method name, e.g. "get_account".
+type Method string
+
+// Param is a single positional JSON-RPC request parameter.
+type Param interface{}
+
+// Request is a single Libra JSON-RPC request.
+type Request struct {
+	ID     string  `json:"id"`
+	Method Method  `json:"method"`
+	Params []Param `json:"params"`
+}
+
+var lastRequestID uint64
+
+// NewRequest creates a Request for method with the given params, assigning it
+// a process-unique ID used to match it against its Response.
+func NewRequest(method Method, params ...Param) *Request {
+	id := atomic.AddUint64(&lastRequestID, 1)
+	return &Request{
+		ID:     strconv.FormatUint(id, 10),
+		Method: method,
+		Params: params,
+	}
+}
+
+// Response is a single Libra JSON-RPC response, including the ledger metadata
+// libraclient uses to detect stale or out-of-order server responses.
+type Response struct {
+	ID                       string          `json:"id"`
+	LibraChainID             int             `json:"libra_chain_id"`
+	LibraLedgerVersion       uint64          `json:"libra_ledger_version"`
+	LibraLedgerTimestampusec uint64          `json:"libra_ledger_timestampusec"`
+	Result                   json.RawMessage `json:"result"`
+	Error                    *ResponseError  `json:"error,omitempty"`
+}
+
+// UnmarshalResult decodes Result into ret. It returns false without error if
+// the server returned a null result (e.g. an account that does not exist).
+func (r *Response) UnmarshalResult(ret interface{}) (bool, error) {
+	if ret == nil || len(r.Result) == 0 || string(r.Result) == "null" {
+		return false, nil
+	}
+	if err := json.Unmarshal(r.Result, ret); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ResponseError is a Libra JSON-RPC error object.
+type ResponseError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("json-rpc error(%v): %v", e.Code, e.Message)
+}
+
+// Client sends Libra JSON-RPC requests to a server and returns responses keyed
+// by request ID.
+type Client interface {
+	Call(req *Request) (map[string]*Response, error)
+	// BatchCall sends all of reqs as a single JSON-RPC batch request, returning
+	// every response keyed by its request ID in one round trip.
+	BatchCall(reqs []*Request) (map[string]*Response, error)
+}
+
+// NewClient creates a Client connected to the given server URL using a default
+// http.Transport.
+func NewClient(url string) Client {
+	return NewClientWithTransport(url, http.DefaultTransport.(*http.Transport))
+}
+
+// NewClientWithTransport creates a Client connected to the given server URL,
+// using the given http.Transport (e.g. for connection pool tuning).
+func NewClientWithTransport(url string, transport *http.Transport) Client {
+	return &httpClient{url: url, http: &http.Client{Transport: transport}}
+}
+
+type httpClient struct {
+	url  string
+	http *http.Client
+}
+
+func (c *httpClient) Call(req *Request) (map[string]*Response, error) {
+	return c.post([]*Request{req})
+}
+
+func (c *httpClient) BatchCall(reqs []*Request) (map[string]*Response, error) {
+	return c.post(reqs)
+}
+
+func (c *httpClient) post(reqs []*Request) (map[string]*Response, error) {
+	body, err := json.Marshal(reqs)
+	if err != nil {
+		return nil, err
+	}
+	httpResp, err := c.http.Post(c.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	var resps []*Response
+	if err := json.NewDecoder(httpResp.Body).Decode(&resps); err != nil {
+		return nil, err
+	}
+	ret := make(map[string]*Response, len(resps))
+	for _, resp := range resps {
+		ret[resp.ID] = resp
+	}
+	return ret, nil
+}
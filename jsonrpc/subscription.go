@@ -0,0 +1,227 @@
+// Copyright (c) The Libra Core Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonrpc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Subscription is a live stream of Responses pushed by the server for a single
+// Subscribe call, modeled on go-ethereum's rpc.ClientSubscription. Each
+// delivered Response carries the same chain-id / ledger-state metadata as a
+// regular Call response, so callers can run it through the same validation.
+// Err() receives at most one terminal error, after which Events() is closed.
+type Subscription interface {
+	Events() <-chan *Response
+	Err() <-chan error
+	Unsubscribe()
+}
+
+// Streamer is implemented by transports that can push server-initiated
+// notifications, currently only SubscriptionClient. The plain http Client does
+// not implement it; callers use this to detect whether a real stream is
+// available or whether they need to fall back to long-polling.
+type Streamer interface {
+	Subscribe(method Method, resubscribe func(last *Response) *Request) (Subscription, error)
+}
+
+// reconnectBackoff bounds how fast SubscriptionClient retries a dropped
+// connection so a persistently unreachable server does not spin a CPU.
+const reconnectBackoff = 500 * time.Millisecond
+
+// SubscriptionClient is a WebSocket-backed Client that additionally supports
+// server-push subscriptions. It reconnects automatically; on every (re)connect
+// it calls resubscribe with the last Response it delivered (nil the first
+// time), so a caller resumes the stream from the next item instead of missing
+// or repeating one.
+type SubscriptionClient struct {
+	url    string
+	dialer *websocket.Dialer
+}
+
+// NewSubscriptionClient creates a SubscriptionClient for the given "ws://" or
+// "wss://" server URL.
+func NewSubscriptionClient(url string) *SubscriptionClient {
+	return &SubscriptionClient{url: url, dialer: websocket.DefaultDialer}
+}
+
+// Call implements Client by issuing req over a short-lived WebSocket
+// connection, so a SubscriptionClient can be used anywhere a plain Client is
+// expected.
+func (c *SubscriptionClient) Call(req *Request) (map[string]*Response, error) {
+	conn, _, err := c.dialer.Dial(c.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := conn.WriteJSON([]*Request{req}); err != nil {
+		return nil, err
+	}
+	var resps []*Response
+	if err := conn.ReadJSON(&resps); err != nil {
+		return nil, err
+	}
+	ret := make(map[string]*Response, len(resps))
+	for _, resp := range resps {
+		ret[resp.ID] = resp
+	}
+	return ret, nil
+}
+
+// BatchCall implements Client by sending all of reqs as a single JSON array
+// over a short-lived WebSocket connection.
+func (c *SubscriptionClient) BatchCall(reqs []*Request) (map[string]*Response, error) {
+	conn, _, err := c.dialer.Dial(c.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := conn.WriteJSON(reqs); err != nil {
+		return nil, err
+	}
+	var resps []*Response
+	if err := conn.ReadJSON(&resps); err != nil {
+		return nil, err
+	}
+	ret := make(map[string]*Response, len(resps))
+	for _, resp := range resps {
+		ret[resp.ID] = resp
+	}
+	return ret, nil
+}
+
+// Subscribe opens a streaming subscription. resubscribe builds the request to
+// send on connect and on every reconnect; it receives the last Response
+// delivered on the previous connection (nil for the initial subscribe) so it
+// can ask the server to resume from there.
+func (c *SubscriptionClient) Subscribe(method Method, resubscribe func(last *Response) *Request) (Subscription, error) {
+	sub := &clientSubscription{
+		dialer:      c.dialer,
+		url:         c.url,
+		resubscribe: resubscribe,
+		events:      make(chan *Response),
+		err:         make(chan error, 1),
+		quit:        make(chan struct{}),
+	}
+	if err := sub.connect(); err != nil {
+		return nil, err
+	}
+	go sub.run()
+	return sub, nil
+}
+
+type clientSubscription struct {
+	dialer      *websocket.Dialer
+	url         string
+	resubscribe func(last *Response) *Request
+
+	connMux sync.Mutex
+	conn    *websocket.Conn
+	last    *Response
+
+	events chan *Response
+	err    chan error
+	quit   chan struct{}
+	once   sync.Once
+}
+
+func (s *clientSubscription) Events() <-chan *Response { return s.events }
+func (s *clientSubscription) Err() <-chan error        { return s.err }
+
+// Unsubscribe stops delivery and releases the underlying connection. It is
+// safe to call more than once or concurrently with run(); run() observes quit
+// closing and exits on its own, closing Events() behind it.
+func (s *clientSubscription) Unsubscribe() {
+	s.once.Do(func() {
+		close(s.quit)
+		s.closeConn()
+	})
+}
+
+func (s *clientSubscription) setConn(conn *websocket.Conn) {
+	s.connMux.Lock()
+	defer s.connMux.Unlock()
+	s.conn = conn
+}
+
+func (s *clientSubscription) getConn() *websocket.Conn {
+	s.connMux.Lock()
+	defer s.connMux.Unlock()
+	return s.conn
+}
+
+func (s *clientSubscription) closeConn() {
+	if conn := s.getConn(); conn != nil {
+		conn.Close()
+	}
+}
+
+func (s *clientSubscription) connect() error {
+	conn, _, err := s.dialer.Dial(s.url, nil)
+	if err != nil {
+		return err
+	}
+	if err := conn.WriteJSON([]*Request{s.resubscribe(s.last)}); err != nil {
+		conn.Close()
+		return err
+	}
+	s.setConn(conn)
+	return nil
+}
+
+// run reads notifications until the connection drops, then reconnects and
+// resubscribes from s.last so the caller never observes a gap. On return it
+// closes Events() so subscribers reading `v, ok := <-sub.Events()` see ok ==
+// false instead of blocking forever.
+func (s *clientSubscription) run() {
+	defer close(s.events)
+	for {
+		select {
+		case <-s.quit:
+			return
+		default:
+		}
+
+		var resp Response
+		if err := s.getConn().ReadJSON(&resp); err != nil {
+			select {
+			case <-s.quit:
+				return
+			default:
+			}
+			if !s.reconnect() {
+				return
+			}
+			continue
+		}
+
+		s.last = &resp
+		select {
+		case s.events <- &resp:
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// reconnect retries connect until it succeeds or Unsubscribe is called,
+// returning false in the latter case.
+func (s *clientSubscription) reconnect() bool {
+	for {
+		select {
+		case <-s.quit:
+			return false
+		default:
+		}
+		if err := s.connect(); err == nil {
+			return true
+		}
+		time.Sleep(reconnectBackoff)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) The Libra Core Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonrpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// fakeSubscriptionServer is a hand-rolled WebSocket server used to drive
+// SubscriptionClient through a drop/reconnect/resubscribe cycle without a real
+// Libra node. Each accepted connection is handed one response from resps (in
+// order) and then dropped, forcing the client to reconnect for the next one.
+type fakeSubscriptionServer struct {
+	t      *testing.T
+	resps  []*Response
+	server *httptest.Server
+
+	mu       sync.Mutex
+	requests []*Request
+}
+
+func newFakeSubscriptionServer(t *testing.T, resps []*Response) *fakeSubscriptionServer {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	fake := &fakeSubscriptionServer{t: t, resps: resps}
+	fake.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var reqs []*Request
+		if err := conn.ReadJSON(&reqs); err != nil || len(reqs) == 0 {
+			return
+		}
+		fake.mu.Lock()
+		connNum := len(fake.requests)
+		fake.requests = append(fake.requests, reqs[0])
+		fake.mu.Unlock()
+
+		if connNum >= len(fake.resps) {
+			return
+		}
+		if err := conn.WriteJSON(fake.resps[connNum]); err != nil {
+			return
+		}
+		if connNum < len(fake.resps)-1 {
+			return // drop the connection, forcing a reconnect for the next response
+		}
+		<-r.Context().Done() // keep the last connection open until the test unsubscribes
+	}))
+	return fake
+}
+
+func (f *fakeSubscriptionServer) url() string {
+	return "ws" + strings.TrimPrefix(f.server.URL, "http")
+}
+
+func (f *fakeSubscriptionServer) requestAt(i int) *Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if i >= len(f.requests) {
+		return nil
+	}
+	return f.requests[i]
+}
+
+func (f *fakeSubscriptionServer) close() {
+	f.server.Close()
+}
+
+// TestSubscriptionClientReconnectsAndResubscribesFromLast drives a
+// SubscriptionClient through one drop/reconnect cycle and asserts that: the
+// notification from before the drop is delivered, the reconnect resubscribes
+// using that notification (so no item is skipped or repeated), and the
+// notification after the reconnect is delivered too.
+func TestSubscriptionClientReconnectsAndResubscribesFromLast(t *testing.T) {
+	first := &Response{ID: "1", LibraLedgerVersion: 10}
+	second := &Response{ID: "2", LibraLedgerVersion: 11}
+	fake := newFakeSubscriptionServer(t, []*Response{first, second})
+	defer fake.close()
+
+	c := NewSubscriptionClient(fake.url())
+	var lastSeen *Response
+	sub, err := c.Subscribe("get_events", func(last *Response) *Request {
+		lastSeen = last
+		start := uint64(0)
+		if last != nil {
+			start = last.LibraLedgerVersion + 1
+		}
+		return NewRequest("get_events", start)
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	select {
+	case got := <-sub.Events():
+		if got.LibraLedgerVersion != first.LibraLedgerVersion {
+			t.Fatalf("got version %d, want %d", got.LibraLedgerVersion, first.LibraLedgerVersion)
+		}
+	case err := <-sub.Err():
+		t.Fatalf("unexpected error before reconnect: %v", err)
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for first notification")
+	}
+
+	select {
+	case got := <-sub.Events():
+		if got.LibraLedgerVersion != second.LibraLedgerVersion {
+			t.Fatalf("got version %d, want %d", got.LibraLedgerVersion, second.LibraLedgerVersion)
+		}
+	case err := <-sub.Err():
+		t.Fatalf("unexpected error after reconnect: %v", err)
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for post-reconnect notification")
+	}
+
+	if lastSeen == nil || lastSeen.LibraLedgerVersion != first.LibraLedgerVersion {
+		t.Fatalf("resubscribe was not called with the last delivered response")
+	}
+	resubscribeReq := fake.requestAt(1)
+	if resubscribeReq == nil || resubscribeReq.Params[0].(float64) != float64(first.LibraLedgerVersion+1) {
+		t.Fatalf("reconnect did not resume from the last delivered version: got %+v", resubscribeReq)
+	}
+
+	sub.Unsubscribe()
+	select {
+	case _, ok := <-sub.Events():
+		if ok {
+			t.Fatal("expected Events() to be closed after Unsubscribe")
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for Events() to close after Unsubscribe")
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright (c) The Libra Core Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package libraclient
+
+import (
+	"fmt"
+
+	"github.com/libra/libra-client-sdk-go/jsonrpc"
+)
+
+// Batch queues typed calls to execute together in a single JSON-RPC round
+// trip via Client.ExecuteBatch, instead of one HTTP request per call. Queue
+// calls with the fluent methods below, then pass the Batch to ExecuteBatch and
+// read each call's typed result off the returned BatchResult in the same
+// order the calls were queued.
+type Batch struct {
+	reqs []*jsonrpc.Request
+}
+
+// NewBatch creates an empty Batch.
+func NewBatch() *Batch {
+	return &Batch{}
+}
+
+// GetCurrencies queues a "get_currencies" call.
+func (b *Batch) GetCurrencies() *Batch {
+	b.reqs = append(b.reqs, jsonrpc.NewRequest(GetCurrencies))
+	return b
+}
+
+// GetMetadata queues a "get_metadata" call for the latest ledger version.
+func (b *Batch) GetMetadata() *Batch {
+	b.reqs = append(b.reqs, jsonrpc.NewRequest(GetMetadata))
+	return b
+}
+
+// GetMetadataByVersion queues a "get_metadata" call for the given version.
+func (b *Batch) GetMetadataByVersion(version uint64) *Batch {
+	b.reqs = append(b.reqs, jsonrpc.NewRequest(GetMetadata, version))
+	return b
+}
+
+// GetAccount queues a "get_account" call.
+func (b *Batch) GetAccount(address Address) *Batch {
+	b.reqs = append(b.reqs, jsonrpc.NewRequest(GetAccount, address))
+	return b
+}
+
+// GetAccountTransaction queues a "get_account_transaction" call.
+func (b *Batch) GetAccountTransaction(address Address, seq uint64, includeEvents bool) *Batch {
+	b.reqs = append(b.reqs, jsonrpc.NewRequest(GetAccountTransaction, address, seq, includeEvents))
+	return b
+}
+
+// GetAccountTransactions queues a "get_account_transactions" call.
+func (b *Batch) GetAccountTransactions(address Address, start uint64, limit uint64, includeEvents bool) *Batch {
+	b.reqs = append(b.reqs, jsonrpc.NewRequest(GetAccountTransactions, address, start, limit, includeEvents))
+	return b
+}
+
+// GetTransactions queues a "get_transactions" call.
+func (b *Batch) GetTransactions(startVersion uint64, limit uint64, includeEvents bool) *Batch {
+	b.reqs = append(b.reqs, jsonrpc.NewRequest(GetTransactions, startVersion, limit, includeEvents))
+	return b
+}
+
+// GetEvents queues a "get_events" call.
+func (b *Batch) GetEvents(key string, start uint64, limit uint64) *Batch {
+	b.reqs = append(b.reqs, jsonrpc.NewRequest(GetEvents, key, start, limit))
+	return b
+}
+
+// ExecuteBatch runs batch in a single JSON-RPC round trip. It validates chain
+// id and ledger staleness across the combined response set (using the
+// maximum LibraLedgerVersion seen) exactly like a single call would; a
+// per-call JSON-RPC error is reported independently when that call's result
+// is read off BatchResult, not by failing the whole batch.
+func (c *client) ExecuteBatch(batch *Batch) (*BatchResult, error) {
+	resps, err := c.rpc.BatchCall(batch.reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	var maxState LedgerState
+	matched := 0
+	for _, req := range batch.reqs {
+		resp, ok := resps[req.ID]
+		if !ok {
+			continue
+		}
+		matched++
+		if err := c.validateChainID(byte(resp.LibraChainID)); err != nil {
+			return nil, err
+		}
+		if resp.LibraLedgerVersion > maxState.Version {
+			maxState = LedgerState{
+				TimestampUsec: resp.LibraLedgerTimestampusec,
+				Version:       resp.LibraLedgerVersion,
+			}
+		}
+	}
+	if matched == 0 {
+		return nil, fmt.Errorf("batch response error: server returned no responses for %d queued call(s)", len(batch.reqs))
+	}
+	if err := c.validateAndUpdateState(maxState); err != nil {
+		return nil, err
+	}
+
+	return &BatchResult{reqs: batch.reqs, resps: resps}, nil
+}
+
+// BatchResult gives per-call access to the responses of an executed Batch.
+// Call the accessor matching each queued call, in order, starting at index 0.
+type BatchResult struct {
+	reqs  []*jsonrpc.Request
+	resps map[string]*jsonrpc.Response
+}
+
+func (r *BatchResult) response(i int) (*jsonrpc.Response, error) {
+	if i < 0 || i >= len(r.reqs) {
+		return nil, fmt.Errorf("batch result index out of range: %d", i)
+	}
+	resp, ok := r.resps[r.reqs[i].ID]
+	if !ok {
+		return nil, fmt.Errorf("batch result missing response for call %d", i)
+	}
+	return resp, nil
+}
+
+// CurrencyInfo reads the result of the i-th call, which must have been queued
+// with GetCurrencies.
+func (r *BatchResult) CurrencyInfo(i int) ([]*CurrencyInfo, error) {
+	resp, err := r.response(i)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	var ret []*CurrencyInfo
+	if _, err := resp.UnmarshalResult(&ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Metadata reads the result of the i-th call, which must have been queued
+// with GetMetadata or GetMetadataByVersion.
+func (r *BatchResult) Metadata(i int) (*Metadata, error) {
+	resp, err := r.response(i)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	var ret Metadata
+	if _, err := resp.UnmarshalResult(&ret); err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// Account reads the result of the i-th call, which must have been queued with
+// GetAccount.
+func (r *BatchResult) Account(i int) (*Account, error) {
+	resp, err := r.response(i)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	var ret Account
+	ok, err := resp.UnmarshalResult(&ret)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// Transaction reads the result of the i-th call, which must have been queued
+// with GetAccountTransaction.
+func (r *BatchResult) Transaction(i int) (*Transaction, error) {
+	resp, err := r.response(i)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	var ret Transaction
+	ok, err := resp.UnmarshalResult(&ret)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// Transactions reads the result of the i-th call, which must have been queued
+// with GetAccountTransactions or GetTransactions.
+func (r *BatchResult) Transactions(i int) ([]*Transaction, error) {
+	resp, err := r.response(i)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	var ret []*Transaction
+	if _, err := resp.UnmarshalResult(&ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Events reads the result of the i-th call, which must have been queued with
+// GetEvents.
+func (r *BatchResult) Events(i int) ([]*Event, error) {
+	resp, err := r.response(i)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	var ret []*Event
+	if _, err := resp.UnmarshalResult(&ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
@@ -0,0 +1,131 @@
+// Copyright (c) The Libra Core Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package libraclient
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/libra/libra-client-sdk-go/jsonrpc"
+)
+
+// fakeBatchRPC is a hand-rolled jsonrpc.Client that answers BatchCall from a
+// canned list of results, one per queued call in order, used to drive
+// ExecuteBatch/BatchResult without a real server.
+type fakeBatchRPC struct {
+	chainID int
+	ledger  LedgerState
+	results []interface{}
+}
+
+func (f *fakeBatchRPC) Call(req *jsonrpc.Request) (map[string]*jsonrpc.Response, error) {
+	panic("not used by these tests")
+}
+
+func (f *fakeBatchRPC) BatchCall(reqs []*jsonrpc.Request) (map[string]*jsonrpc.Response, error) {
+	ret := make(map[string]*jsonrpc.Response, len(reqs))
+	for i, req := range reqs {
+		data, err := json.Marshal(f.results[i])
+		if err != nil {
+			return nil, err
+		}
+		ret[req.ID] = &jsonrpc.Response{
+			LibraChainID:             f.chainID,
+			LibraLedgerVersion:       f.ledger.Version,
+			LibraLedgerTimestampusec: f.ledger.TimestampUsec,
+			Result:                   data,
+		}
+	}
+	return ret, nil
+}
+
+func TestExecuteBatch(t *testing.T) {
+	var account Account
+	account.SequenceNumber = 3
+
+	rpc := &fakeBatchRPC{
+		chainID: int(testChainID),
+		ledger:  LedgerState{Version: 5, TimestampUsec: 5},
+		results: []interface{}{&account, []*CurrencyInfo{{Code: "LBR"}}},
+	}
+	c := &client{chainID: testChainID, rpc: rpc}
+
+	batch := NewBatch().GetAccount(Address("deadbeef")).GetCurrencies()
+	result, err := c.ExecuteBatch(batch)
+	if err != nil {
+		t.Fatalf("ExecuteBatch: %v", err)
+	}
+
+	gotAccount, err := result.Account(0)
+	if err != nil {
+		t.Fatalf("Account(0): %v", err)
+	}
+	if gotAccount.SequenceNumber != 3 {
+		t.Fatalf("got sequence number %d, want 3", gotAccount.SequenceNumber)
+	}
+
+	gotCurrencies, err := result.CurrencyInfo(1)
+	if err != nil {
+		t.Fatalf("CurrencyInfo(1): %v", err)
+	}
+	if len(gotCurrencies) != 1 || gotCurrencies[0].Code != "LBR" {
+		t.Fatalf("got %+v, want one CurrencyInfo with code LBR", gotCurrencies)
+	}
+
+	if got := c.LastResponseLedgerState(); got.Version != 5 {
+		t.Fatalf("LastResponseLedgerState version = %d, want 5 (max across batch)", got.Version)
+	}
+}
+
+func TestExecuteBatchChainIDMismatch(t *testing.T) {
+	rpc := &fakeBatchRPC{
+		chainID: int(testChainID) + 1,
+		ledger:  LedgerState{Version: 1, TimestampUsec: 1},
+		results: []interface{}{&Account{}},
+	}
+	c := &client{chainID: testChainID, rpc: rpc}
+
+	_, err := c.ExecuteBatch(NewBatch().GetAccount(Address("deadbeef")))
+	if err == nil {
+		t.Fatal("expected chain id mismatch error, got nil")
+	}
+}
+
+// droppingBatchRPC simulates a server that returns no responses at all for a
+// batch, to exercise ExecuteBatch's handling of a wholly-unmatched batch.
+type droppingBatchRPC struct{}
+
+func (droppingBatchRPC) Call(req *jsonrpc.Request) (map[string]*jsonrpc.Response, error) {
+	panic("not used by these tests")
+}
+
+func (droppingBatchRPC) BatchCall(reqs []*jsonrpc.Request) (map[string]*jsonrpc.Response, error) {
+	return map[string]*jsonrpc.Response{}, nil
+}
+
+func TestExecuteBatchNoResponsesMatched(t *testing.T) {
+	c := &client{chainID: testChainID, rpc: droppingBatchRPC{}}
+
+	_, err := c.ExecuteBatch(NewBatch().GetAccount(Address("deadbeef")))
+	if err == nil {
+		t.Fatal("expected an error when the server returns no responses for the batch, got nil")
+	}
+}
+
+func TestBatchResultIndexOutOfRange(t *testing.T) {
+	rpc := &fakeBatchRPC{
+		chainID: int(testChainID),
+		ledger:  LedgerState{Version: 1, TimestampUsec: 1},
+		results: []interface{}{&Account{}},
+	}
+	c := &client{chainID: testChainID, rpc: rpc}
+
+	result, err := c.ExecuteBatch(NewBatch().GetAccount(Address("deadbeef")))
+	if err != nil {
+		t.Fatalf("ExecuteBatch: %v", err)
+	}
+	if _, err := result.Account(1); err == nil {
+		t.Fatal("expected out-of-range error, got nil")
+	}
+}
@@ -37,6 +37,10 @@ type Client interface {
 	GetTransactions(uint64, uint64, bool) ([]*Transaction, error)
 	GetEvents(string, uint64, uint64) ([]*Event, error)
 	Submit(string) error
+	ExecuteBatch(batch *Batch) (*BatchResult, error)
+	SubscribeAccountTransactions(address Address, startSeq uint64) (TransactionSubscription, error)
+	SubscribeEvents(key string, start uint64) (EventSubscription, error)
+	SubscribeNewTransactions(startVersion uint64) (TransactionSubscription, error)
 	WaitForTransaction(
 		address Address,
 		seq uint64,
@@ -207,23 +211,28 @@ func (c *client) call(method jsonrpc.Method, ret interface{}, params ...jsonrpc.
 	}
 	resp := resps[req.ID]
 
-	if err = c.validateChainID(byte(resp.LibraChainID)); err != nil {
+	if err := c.validateResponse(resp); err != nil {
 		return false, err
 	}
-	err = c.validateAndUpdateState(LedgerState{
-		TimestampUsec: resp.LibraLedgerTimestampusec,
-		Version:       resp.LibraLedgerVersion,
-	})
-	if err != nil {
-		return false, err
-	}
-
 	if resp.Error != nil {
 		return false, resp.Error
 	}
 	return resp.UnmarshalResult(ret)
 }
 
+// validateResponse runs the chain-id and ledger-state staleness checks shared
+// by every response `call` receives, and by every notification delivered over
+// a Subscribe* stream.
+func (c *client) validateResponse(resp *jsonrpc.Response) error {
+	if err := c.validateChainID(byte(resp.LibraChainID)); err != nil {
+		return err
+	}
+	return c.validateAndUpdateState(LedgerState{
+		TimestampUsec: resp.LibraLedgerTimestampusec,
+		Version:       resp.LibraLedgerVersion,
+	})
+}
+
 func (c *client) validateChainID(chainID byte) error {
 	if c.chainID != chainID {
 		return fmt.Errorf("chain id mismatch error: expected server response chain id == %d, but got %d", c.chainID, chainID)
@@ -0,0 +1,379 @@
+// Copyright (c) The Libra Core Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package libraclient
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/libra/libra-client-sdk-go/jsonrpc"
+)
+
+// longPollInterval is how often a Subscribe* call re-polls the server when the
+// configured jsonrpc.Client does not support streaming (e.g. a plain http URL).
+const longPollInterval = time.Second
+
+// TransactionSubscription streams Transactions delivered by
+// SubscribeAccountTransactions or SubscribeNewTransactions.
+type TransactionSubscription interface {
+	Events() <-chan *Transaction
+	Err() <-chan error
+	Unsubscribe()
+}
+
+// EventSubscription streams Events delivered by SubscribeEvents.
+type EventSubscription interface {
+	Events() <-chan *Event
+	Err() <-chan error
+	Unsubscribe()
+}
+
+// SubscribeAccountTransactions follows the given account's transactions,
+// starting at startSeq (inclusive). If the underlying jsonrpc.Client supports
+// streaming it is used directly; otherwise this falls back to a long-poll
+// emulation that repeatedly calls GetAccountTransaction.
+func (c *client) SubscribeAccountTransactions(address Address, startSeq uint64) (TransactionSubscription, error) {
+	if streamer, ok := c.rpc.(jsonrpc.Streamer); ok {
+		raw, err := streamer.Subscribe(GetAccountTransaction, func(last *jsonrpc.Response) *jsonrpc.Request {
+			seq := nextAccountTransactionSeq(last, startSeq)
+			return jsonrpc.NewRequest(GetAccountTransaction, address, seq, true)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return c.streamTransactions(raw), nil
+	}
+
+	return c.longPollTransactions(func(seq uint64) ([]*Transaction, error) {
+		txn, err := c.GetAccountTransaction(address, seq, true)
+		if err != nil || txn == nil {
+			return nil, err
+		}
+		return []*Transaction{txn}, nil
+	}, startSeq), nil
+}
+
+// SubscribeNewTransactions follows every transaction committed to the chain,
+// starting at startVersion (inclusive).
+func (c *client) SubscribeNewTransactions(startVersion uint64) (TransactionSubscription, error) {
+	if streamer, ok := c.rpc.(jsonrpc.Streamer); ok {
+		raw, err := streamer.Subscribe(GetTransactions, func(last *jsonrpc.Response) *jsonrpc.Request {
+			version := nextTransactionVersion(last, startVersion)
+			return jsonrpc.NewRequest(GetTransactions, version, uint64(1), true)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return c.streamTransactions(raw), nil
+	}
+
+	return c.longPollTransactions(func(version uint64) ([]*Transaction, error) {
+		return c.GetTransactions(version, 100, true)
+	}, startVersion), nil
+}
+
+// SubscribeEvents follows the event stream identified by key, starting at
+// start (inclusive).
+func (c *client) SubscribeEvents(key string, start uint64) (EventSubscription, error) {
+	if streamer, ok := c.rpc.(jsonrpc.Streamer); ok {
+		raw, err := streamer.Subscribe(GetEvents, func(last *jsonrpc.Response) *jsonrpc.Request {
+			seq := nextEventSeq(last, start)
+			return jsonrpc.NewRequest(GetEvents, key, seq, uint64(1))
+		})
+		if err != nil {
+			return nil, err
+		}
+		return c.streamEvents(raw), nil
+	}
+
+	return c.longPollEvents(func(seq uint64) ([]*Event, error) {
+		return c.GetEvents(key, seq, 100)
+	}, start), nil
+}
+
+// nextAccountTransactionSeq returns the sequence number a SubscribeAccountTransactions
+// resubscribe should resume from: one past the last transaction actually
+// delivered, decoded straight out of last, so a reconnect always resumes from
+// the real last-seen item no matter how many (or how few) notifications
+// streamed since the previous connect. It falls back to start when last is
+// nil (the initial subscribe) or fails to decode.
+func nextAccountTransactionSeq(last *jsonrpc.Response, start uint64) uint64 {
+	if last == nil {
+		return start
+	}
+	var txn Transaction
+	if ok, err := last.UnmarshalResult(&txn); err != nil || !ok {
+		return start
+	}
+	return txn.Transaction.SequenceNumber + 1
+}
+
+// nextTransactionVersion is the SubscribeNewTransactions equivalent of
+// nextAccountTransactionSeq: it resumes one past the highest ledger version
+// actually delivered.
+func nextTransactionVersion(last *jsonrpc.Response, start uint64) uint64 {
+	if last == nil {
+		return start
+	}
+	var txns []*Transaction
+	if ok, err := last.UnmarshalResult(&txns); err != nil || !ok || len(txns) == 0 {
+		return start
+	}
+	return txns[len(txns)-1].Version + 1
+}
+
+// nextEventSeq is the SubscribeEvents equivalent of nextAccountTransactionSeq:
+// it resumes one past the highest event sequence number actually delivered.
+func nextEventSeq(last *jsonrpc.Response, start uint64) uint64 {
+	if last == nil {
+		return start
+	}
+	var events []*Event
+	if ok, err := last.UnmarshalResult(&events); err != nil || !ok || len(events) == 0 {
+		return start
+	}
+	return events[len(events)-1].SequenceNumber + 1
+}
+
+// streamTransactions decodes a raw jsonrpc.Subscription of "get_*transaction*"
+// responses into a TransactionSubscription, running every delivered
+// notification through the same chain-id / ledger-state validation as `call`.
+func (c *client) streamTransactions(raw jsonrpc.Subscription) TransactionSubscription {
+	out := &transactionSubscription{
+		events: make(chan *Transaction),
+		err:    make(chan error, 1),
+	}
+	quit := make(chan struct{})
+	var once sync.Once
+	out.unsubscribe = func() {
+		once.Do(func() {
+			close(quit)
+			raw.Unsubscribe()
+		})
+	}
+	go func() {
+		defer close(out.events)
+		for {
+			select {
+			case resp, ok := <-raw.Events():
+				if !ok {
+					return
+				}
+				txns, err := c.decodeTransactions(resp)
+				if err != nil {
+					out.err <- err
+					return
+				}
+				for _, txn := range txns {
+					select {
+					case out.events <- txn:
+					case <-quit:
+						return
+					}
+				}
+			case err := <-raw.Err():
+				out.err <- err
+				return
+			case <-quit:
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func (c *client) streamEvents(raw jsonrpc.Subscription) EventSubscription {
+	out := &eventSubscription{
+		events: make(chan *Event),
+		err:    make(chan error, 1),
+	}
+	quit := make(chan struct{})
+	var once sync.Once
+	out.unsubscribe = func() {
+		once.Do(func() {
+			close(quit)
+			raw.Unsubscribe()
+		})
+	}
+	go func() {
+		defer close(out.events)
+		for {
+			select {
+			case resp, ok := <-raw.Events():
+				if !ok {
+					return
+				}
+				events, err := c.decodeEvents(resp)
+				if err != nil {
+					out.err <- err
+					return
+				}
+				for _, event := range events {
+					select {
+					case out.events <- event:
+					case <-quit:
+						return
+					}
+				}
+			case err := <-raw.Err():
+				out.err <- err
+				return
+			case <-quit:
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// longPollTransactions repeatedly calls fetch(cursor), advancing cursor by the
+// number of transactions it returned, emulating a stream for a jsonrpc.Client
+// that cannot push notifications.
+func (c *client) longPollTransactions(fetch func(cursor uint64) ([]*Transaction, error), start uint64) TransactionSubscription {
+	out := &transactionSubscription{events: make(chan *Transaction), err: make(chan error, 1)}
+	quit := make(chan struct{})
+	var once sync.Once
+	out.unsubscribe = func() {
+		once.Do(func() {
+			close(quit)
+		})
+	}
+	go func() {
+		defer close(out.events)
+		cursor := start
+		ticker := time.NewTicker(longPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-quit:
+				return
+			case <-ticker.C:
+				txns, err := fetch(cursor)
+				if err != nil {
+					out.err <- err
+					return
+				}
+				for _, txn := range txns {
+					select {
+					case out.events <- txn:
+						cursor++
+					case <-quit:
+						return
+					}
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// longPollEvents is the SubscribeEvents equivalent of longPollTransactions: it
+// repeatedly calls fetch(cursor), advancing cursor by the number of events it
+// returned, emulating a stream for a jsonrpc.Client that cannot push
+// notifications.
+func (c *client) longPollEvents(fetch func(cursor uint64) ([]*Event, error), start uint64) EventSubscription {
+	out := &eventSubscription{events: make(chan *Event), err: make(chan error, 1)}
+	quit := make(chan struct{})
+	var once sync.Once
+	out.unsubscribe = func() {
+		once.Do(func() {
+			close(quit)
+		})
+	}
+	go func() {
+		defer close(out.events)
+		cursor := start
+		ticker := time.NewTicker(longPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-quit:
+				return
+			case <-ticker.C:
+				events, err := fetch(cursor)
+				if err != nil {
+					out.err <- err
+					return
+				}
+				for _, event := range events {
+					select {
+					case out.events <- event:
+						cursor++
+					case <-quit:
+						return
+					}
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// decodeTransactions validates resp and unmarshals its result into either a
+// single Transaction or a list of them, normalizing both shapes into a slice.
+// The shape is sniffed off the raw JSON rather than inferred from unmarshal
+// success, since unmarshaling a single object into a slice fails with an
+// error rather than ok == false.
+func (c *client) decodeTransactions(resp *jsonrpc.Response) ([]*Transaction, error) {
+	if err := c.validateResponse(resp); err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	if isJSONArray(resp.Result) {
+		var list []*Transaction
+		_, err := resp.UnmarshalResult(&list)
+		return list, err
+	}
+	var txn Transaction
+	ok, err := resp.UnmarshalResult(&txn)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return []*Transaction{&txn}, nil
+}
+
+// isJSONArray reports whether raw's first non-whitespace byte opens a JSON
+// array, to distinguish a single-object result from a list result before
+// choosing which shape to unmarshal into.
+func isJSONArray(raw []byte) bool {
+	trimmed := bytes.TrimLeft(raw, " \t\r\n")
+	return len(trimmed) > 0 && trimmed[0] == '['
+}
+
+func (c *client) decodeEvents(resp *jsonrpc.Response) ([]*Event, error) {
+	if err := c.validateResponse(resp); err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	var events []*Event
+	if _, err := resp.UnmarshalResult(&events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+type transactionSubscription struct {
+	events      chan *Transaction
+	err         chan error
+	unsubscribe func()
+}
+
+func (s *transactionSubscription) Events() <-chan *Transaction { return s.events }
+func (s *transactionSubscription) Err() <-chan error           { return s.err }
+func (s *transactionSubscription) Unsubscribe()                { s.unsubscribe() }
+
+type eventSubscription struct {
+	events      chan *Event
+	err         chan error
+	unsubscribe func()
+}
+
+func (s *eventSubscription) Events() <-chan *Event { return s.events }
+func (s *eventSubscription) Err() <-chan error     { return s.err }
+func (s *eventSubscription) Unsubscribe()          { s.unsubscribe() }
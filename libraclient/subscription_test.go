@@ -0,0 +1,251 @@
+// Copyright (c) The Libra Core Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package libraclient
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/libra/libra-client-sdk-go/jsonrpc"
+)
+
+const testChainID byte = 2
+
+func mustResponse(t *testing.T, result interface{}) *jsonrpc.Response {
+	t.Helper()
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("marshal result: %v", err)
+	}
+	return &jsonrpc.Response{
+		LibraChainID:             int(testChainID),
+		LibraLedgerVersion:       1,
+		LibraLedgerTimestampusec: 1,
+		Result:                   data,
+	}
+}
+
+func TestNextAccountTransactionSeq(t *testing.T) {
+	if got := nextAccountTransactionSeq(nil, 5); got != 5 {
+		t.Fatalf("nil last: got %d, want 5 (start)", got)
+	}
+
+	var txn Transaction
+	txn.Transaction.SequenceNumber = 7
+	if got := nextAccountTransactionSeq(mustResponse(t, &txn), 5); got != 8 {
+		t.Fatalf("decodable last: got %d, want 8 (last seq + 1)", got)
+	}
+
+	undecodable := &jsonrpc.Response{Result: json.RawMessage("null")}
+	if got := nextAccountTransactionSeq(undecodable, 5); got != 5 {
+		t.Fatalf("undecodable last: got %d, want 5 (start)", got)
+	}
+}
+
+func TestNextTransactionVersion(t *testing.T) {
+	if got := nextTransactionVersion(nil, 9); got != 9 {
+		t.Fatalf("nil last: got %d, want 9 (start)", got)
+	}
+
+	var first, second Transaction
+	first.Version = 10
+	second.Version = 11
+	if got := nextTransactionVersion(mustResponse(t, []*Transaction{&first, &second}), 9); got != 12 {
+		t.Fatalf("decodable last: got %d, want 12 (highest version + 1)", got)
+	}
+
+	empty := mustResponse(t, []*Transaction{})
+	if got := nextTransactionVersion(empty, 9); got != 9 {
+		t.Fatalf("empty last: got %d, want 9 (start)", got)
+	}
+}
+
+func TestNextEventSeq(t *testing.T) {
+	if got := nextEventSeq(nil, 3); got != 3 {
+		t.Fatalf("nil last: got %d, want 3 (start)", got)
+	}
+
+	var first, second Event
+	first.SequenceNumber = 4
+	second.SequenceNumber = 5
+	if got := nextEventSeq(mustResponse(t, []*Event{&first, &second}), 3); got != 6 {
+		t.Fatalf("decodable last: got %d, want 6 (highest seq + 1)", got)
+	}
+}
+
+// fakeRawSubscription is a hand-rolled jsonrpc.Subscription used to drive
+// streamTransactions/streamEvents without a real WebSocket connection.
+type fakeRawSubscription struct {
+	events      chan *jsonrpc.Response
+	err         chan error
+	unsubscribe func()
+}
+
+func newFakeRawSubscription() *fakeRawSubscription {
+	sub := &fakeRawSubscription{
+		events: make(chan *jsonrpc.Response),
+		err:    make(chan error, 1),
+	}
+	sub.unsubscribe = func() { close(sub.events) }
+	return sub
+}
+
+func (f *fakeRawSubscription) Events() <-chan *jsonrpc.Response { return f.events }
+func (f *fakeRawSubscription) Err() <-chan error                { return f.err }
+func (f *fakeRawSubscription) Unsubscribe()                     { f.unsubscribe() }
+
+func TestStreamTransactionsForwardsAndClosesOnUnsubscribe(t *testing.T) {
+	c := &client{chainID: testChainID}
+	raw := newFakeRawSubscription()
+	sub := c.streamTransactions(raw)
+
+	var txn Transaction
+	txn.Transaction.SequenceNumber = 1
+	raw.events <- mustResponse(t, &txn)
+
+	select {
+	case got := <-sub.Events():
+		if got.Transaction.SequenceNumber != 1 {
+			t.Fatalf("got sequence number %d, want 1", got.Transaction.SequenceNumber)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for forwarded transaction")
+	}
+
+	sub.Unsubscribe()
+	select {
+	case _, ok := <-sub.Events():
+		if ok {
+			t.Fatal("expected Events() to be closed after Unsubscribe")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for Events() to close after Unsubscribe")
+	}
+}
+
+// TestStreamTransactionsUnsubscribeWithoutDrainingUndeliveredItems reproduces
+// a caller that stops reading Events() as soon as it has what it needs (the
+// common `for txn := range sub.Events() { ...; sub.Unsubscribe(); break }`
+// pattern), leaving a second decoded item from the same notification
+// unforwarded. The forwarding goroutine must still exit instead of blocking
+// forever on the unbuffered out.events send.
+func TestStreamTransactionsUnsubscribeWithoutDrainingUndeliveredItems(t *testing.T) {
+	c := &client{chainID: testChainID}
+	raw := newFakeRawSubscription()
+	sub := c.streamTransactions(raw)
+
+	var first, second Transaction
+	first.Transaction.SequenceNumber = 1
+	second.Transaction.SequenceNumber = 2
+	raw.events <- mustResponse(t, []*Transaction{&first, &second})
+
+	select {
+	case got := <-sub.Events():
+		if got.Transaction.SequenceNumber != 1 {
+			t.Fatalf("got sequence number %d, want 1", got.Transaction.SequenceNumber)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for forwarded transaction")
+	}
+
+	sub.Unsubscribe()
+	select {
+	case _, ok := <-sub.Events():
+		if ok {
+			t.Fatal("expected Events() to be closed after Unsubscribe")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("forwarding goroutine leaked: blocked sending the undelivered second item")
+	}
+}
+
+func TestLongPollTransactionsForwardsAndClosesOnUnsubscribe(t *testing.T) {
+	c := &client{}
+	fetch := func(cursor uint64) ([]*Transaction, error) {
+		var txn Transaction
+		txn.Transaction.SequenceNumber = cursor
+		return []*Transaction{&txn}, nil
+	}
+	sub := c.longPollTransactions(fetch, 1)
+
+	select {
+	case got := <-sub.Events():
+		if got.Transaction.SequenceNumber != 1 {
+			t.Fatalf("got sequence number %d, want 1", got.Transaction.SequenceNumber)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for forwarded transaction")
+	}
+
+	sub.Unsubscribe()
+	sub.Unsubscribe() // must not panic
+	select {
+	case _, ok := <-sub.Events():
+		if ok {
+			t.Fatal("expected Events() to be closed after Unsubscribe")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for Events() to close after Unsubscribe")
+	}
+}
+
+func TestLongPollEventsForwardsAndClosesOnUnsubscribe(t *testing.T) {
+	c := &client{}
+	fetch := func(cursor uint64) ([]*Event, error) {
+		var event Event
+		event.SequenceNumber = cursor
+		return []*Event{&event}, nil
+	}
+	sub := c.longPollEvents(fetch, 9)
+
+	select {
+	case got := <-sub.Events():
+		if got.SequenceNumber != 9 {
+			t.Fatalf("got sequence number %d, want 9", got.SequenceNumber)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for forwarded event")
+	}
+
+	sub.Unsubscribe()
+	sub.Unsubscribe() // must not panic
+	select {
+	case _, ok := <-sub.Events():
+		if ok {
+			t.Fatal("expected Events() to be closed after Unsubscribe")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for Events() to close after Unsubscribe")
+	}
+}
+
+func TestStreamEventsForwardsAndClosesOnUnsubscribe(t *testing.T) {
+	c := &client{chainID: testChainID}
+	raw := newFakeRawSubscription()
+	sub := c.streamEvents(raw)
+
+	var event Event
+	event.SequenceNumber = 9
+	raw.events <- mustResponse(t, []*Event{&event})
+
+	select {
+	case got := <-sub.Events():
+		if got.SequenceNumber != 9 {
+			t.Fatalf("got sequence number %d, want 9", got.SequenceNumber)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for forwarded event")
+	}
+
+	sub.Unsubscribe()
+	select {
+	case _, ok := <-sub.Events():
+		if ok {
+			t.Fatal("expected Events() to be closed after Unsubscribe")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for Events() to close after Unsubscribe")
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) The Libra Core Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mobile wraps libraclient for gomobile bind, following the pattern
+// go-ethereum uses for its mobile/ethclient.go wrappers. gomobile cannot cross
+// the language boundary with Go interfaces, variadic funcs, []*T slices,
+// uint64, or time.Duration, so every exported method here accepts and returns
+// only strings, int64, bools, byte slices, or exported struct pointers.
+package mobile
+
+import (
+	"time"
+
+	"github.com/libra/libra-client-sdk-go/libraclient"
+)
+
+// Client is a gomobile-bindable wrapper around libraclient.Client.
+type Client struct {
+	client libraclient.Client
+}
+
+// NewClient creates a Client connected to the given server URL for chainID.
+func NewClient(chainID int64, url string) *Client {
+	return &Client{client: libraclient.New(byte(chainID), url)}
+}
+
+// GetCurrencies calls libraclient.Client.GetCurrencies.
+func (c *Client) GetCurrencies() (*CurrencyInfos, error) {
+	ret, err := c.client.GetCurrencies()
+	if err != nil {
+		return nil, err
+	}
+	return &CurrencyInfos{infos: ret}, nil
+}
+
+// GetMetadata calls libraclient.Client.GetMetadata.
+func (c *Client) GetMetadata() (*Metadata, error) {
+	ret, err := c.client.GetMetadata()
+	if err != nil {
+		return nil, err
+	}
+	return &Metadata{metadata: ret}, nil
+}
+
+// GetMetadataByVersion calls libraclient.Client.GetMetadataByVersion.
+func (c *Client) GetMetadataByVersion(version int64) (*Metadata, error) {
+	ret, err := c.client.GetMetadataByVersion(uint64(version))
+	if err != nil {
+		return nil, err
+	}
+	return &Metadata{metadata: ret}, nil
+}
+
+// GetAccount calls libraclient.Client.GetAccount. It returns a nil Account and
+// a nil error when the account does not exist.
+func (c *Client) GetAccount(addressHex string) (*Account, error) {
+	ret, err := c.client.GetAccount(libraclient.Address(addressHex))
+	if err != nil || ret == nil {
+		return nil, err
+	}
+	return &Account{account: ret}, nil
+}
+
+// GetAccountTransaction calls libraclient.Client.GetAccountTransaction. It
+// returns a nil Transaction and a nil error when the transaction is not found.
+func (c *Client) GetAccountTransaction(addressHex string, seq int64, includeEvents bool) (*Transaction, error) {
+	ret, err := c.client.GetAccountTransaction(libraclient.Address(addressHex), uint64(seq), includeEvents)
+	if err != nil || ret == nil {
+		return nil, err
+	}
+	return &Transaction{txn: ret}, nil
+}
+
+// GetAccountTransactions calls libraclient.Client.GetAccountTransactions.
+func (c *Client) GetAccountTransactions(addressHex string, start int64, limit int64, includeEvents bool) (*Transactions, error) {
+	ret, err := c.client.GetAccountTransactions(libraclient.Address(addressHex), uint64(start), uint64(limit), includeEvents)
+	if err != nil {
+		return nil, err
+	}
+	return &Transactions{txns: ret}, nil
+}
+
+// GetTransactions calls libraclient.Client.GetTransactions.
+func (c *Client) GetTransactions(startVersion int64, limit int64, includeEvents bool) (*Transactions, error) {
+	ret, err := c.client.GetTransactions(uint64(startVersion), uint64(limit), includeEvents)
+	if err != nil {
+		return nil, err
+	}
+	return &Transactions{txns: ret}, nil
+}
+
+// GetEvents calls libraclient.Client.GetEvents.
+func (c *Client) GetEvents(key string, start int64, limit int64) (*Events, error) {
+	ret, err := c.client.GetEvents(key, uint64(start), uint64(limit))
+	if err != nil {
+		return nil, err
+	}
+	return &Events{events: ret}, nil
+}
+
+// Submit calls libraclient.Client.Submit with a hex-encoded LCS transaction.
+func (c *Client) Submit(hexTransaction string) error {
+	return c.client.Submit(hexTransaction)
+}
+
+// WaitForTransaction calls libraclient.Client.WaitForTransaction, taking
+// timeoutMillis instead of a time.Duration, which gomobile cannot bind.
+func (c *Client) WaitForTransaction(addressHex string, seq int64, signature string, expirationTimeSec int64, timeoutMillis int64) (*Transaction, error) {
+	ret, err := c.client.WaitForTransaction(
+		libraclient.Address(addressHex),
+		uint64(seq),
+		signature,
+		uint64(expirationTimeSec),
+		time.Duration(timeoutMillis)*time.Millisecond,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Transaction{txn: ret}, nil
+}
+
+// LastResponseLedgerState calls libraclient.Client.LastResponseLedgerState.
+func (c *Client) LastResponseLedgerState() *LedgerState {
+	return &LedgerState{state: c.client.LastResponseLedgerState()}
+}
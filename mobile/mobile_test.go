@@ -0,0 +1,50 @@
+// Copyright (c) The Libra Core Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package mobile
+
+import (
+	"testing"
+
+	"github.com/libra/libra-client-sdk-go/libraclient"
+)
+
+// TestWrappersDelegateToLibraclient is a smoke test that the gomobile-bound
+// wrapper types only expose getters gomobile can bind (strings, int64, byte
+// slices, exported struct pointers) and correctly read through to the
+// underlying libraclient types.
+func TestWrappersDelegateToLibraclient(t *testing.T) {
+	account := &Account{account: &libraclient.Account{
+		SequenceNumber: 42,
+		SentEventsKey:  "sent-key",
+	}}
+	if got := account.SequenceNumber(); got != 42 {
+		t.Fatalf("SequenceNumber() = %d, want 42", got)
+	}
+	if got := account.SentEventsKey(); got != "sent-key" {
+		t.Fatalf("SentEventsKey() = %q, want %q", got, "sent-key")
+	}
+
+	state := &LedgerState{state: libraclient.LedgerState{TimestampUsec: 1, Version: 2}}
+	if got := state.TimestampUsec(); got != 1 {
+		t.Fatalf("TimestampUsec() = %d, want 1", got)
+	}
+	if got := state.Version(); got != 2 {
+		t.Fatalf("Version() = %d, want 2", got)
+	}
+
+	txns := &Transactions{txns: []*libraclient.Transaction{{}, {}}}
+	if got := txns.Size(); got != 2 {
+		t.Fatalf("Size() = %d, want 2", got)
+	}
+	txn, err := txns.Get(0)
+	if err != nil {
+		t.Fatalf("Get(0): %v", err)
+	}
+	if txn == nil {
+		t.Fatal("Get(0) = nil, want non-nil Transaction")
+	}
+	if _, err := txns.Get(2); err == nil {
+		t.Fatal("Get(2) on a 2-element list: expected out-of-range error, got nil")
+	}
+}
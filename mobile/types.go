@@ -0,0 +1,127 @@
+// Copyright (c) The Libra Core Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package mobile
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/libra/libra-client-sdk-go/libraclient"
+)
+
+func marshal(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// LedgerState wraps libraclient.LedgerState with getters, since gomobile
+// cannot bind struct fields typed uint64.
+type LedgerState struct {
+	state libraclient.LedgerState
+}
+
+func (s *LedgerState) TimestampUsec() int64 { return int64(s.state.TimestampUsec) }
+func (s *LedgerState) Version() int64       { return int64(s.state.Version) }
+
+// Account wraps libraclient.Account with getters for the fields callers need
+// most; JSON exposes the rest for gomobile, which cannot bind struct fields
+// directly.
+type Account struct {
+	account *libraclient.Account
+}
+
+func (a *Account) SequenceNumber() int64     { return int64(a.account.SequenceNumber) }
+func (a *Account) SentEventsKey() string     { return a.account.SentEventsKey }
+func (a *Account) ReceivedEventsKey() string { return a.account.ReceivedEventsKey }
+func (a *Account) JSON() (string, error)     { return marshal(a.account) }
+
+// Transaction wraps libraclient.Transaction with getters for the fields
+// WaitForTransaction callers need most; JSON exposes the rest.
+type Transaction struct {
+	txn *libraclient.Transaction
+}
+
+func (t *Transaction) Signature() string     { return t.txn.Transaction.Signature }
+func (t *Transaction) VmStatusType() string  { return t.txn.VmStatus.Type }
+func (t *Transaction) JSON() (string, error) { return marshal(t.txn) }
+
+// Transactions wraps a []*libraclient.Transaction with Size()/Get(i) index
+// accessors, since gomobile cannot bind a Go slice of pointers.
+type Transactions struct {
+	txns []*libraclient.Transaction
+}
+
+func (t *Transactions) Size() int64 { return int64(len(t.txns)) }
+
+// Get returns the i-th Transaction, or an error if i is out of range: a panic
+// crossing the gomobile boundary is not catchable on the Swift/Kotlin side and
+// would crash the host app.
+func (t *Transactions) Get(i int64) (*Transaction, error) {
+	if i < 0 || i >= int64(len(t.txns)) {
+		return nil, fmt.Errorf("transaction index out of range: %d", i)
+	}
+	return &Transaction{txn: t.txns[i]}, nil
+}
+
+// Event wraps libraclient.Event, exposing it as JSON since gomobile cannot
+// bind its fields directly.
+type Event struct {
+	event *libraclient.Event
+}
+
+func (e *Event) JSON() (string, error) { return marshal(e.event) }
+
+// Events wraps a []*libraclient.Event with Size()/Get(i) index accessors.
+type Events struct {
+	events []*libraclient.Event
+}
+
+func (e *Events) Size() int64 { return int64(len(e.events)) }
+
+// Get returns the i-th Event, or an error if i is out of range: a panic
+// crossing the gomobile boundary is not catchable on the Swift/Kotlin side and
+// would crash the host app.
+func (e *Events) Get(i int64) (*Event, error) {
+	if i < 0 || i >= int64(len(e.events)) {
+		return nil, fmt.Errorf("event index out of range: %d", i)
+	}
+	return &Event{event: e.events[i]}, nil
+}
+
+// Metadata wraps libraclient.Metadata, exposing it as JSON since gomobile
+// cannot bind its fields directly.
+type Metadata struct {
+	metadata *libraclient.Metadata
+}
+
+func (m *Metadata) JSON() (string, error) { return marshal(m.metadata) }
+
+// CurrencyInfo wraps libraclient.CurrencyInfo, exposing it as JSON since
+// gomobile cannot bind its fields directly.
+type CurrencyInfo struct {
+	info *libraclient.CurrencyInfo
+}
+
+func (c *CurrencyInfo) JSON() (string, error) { return marshal(c.info) }
+
+// CurrencyInfos wraps a []*libraclient.CurrencyInfo with Size()/Get(i) index
+// accessors.
+type CurrencyInfos struct {
+	infos []*libraclient.CurrencyInfo
+}
+
+func (c *CurrencyInfos) Size() int64 { return int64(len(c.infos)) }
+
+// Get returns the i-th CurrencyInfo, or an error if i is out of range: a
+// panic crossing the gomobile boundary is not catchable on the Swift/Kotlin
+// side and would crash the host app.
+func (c *CurrencyInfos) Get(i int64) (*CurrencyInfo, error) {
+	if i < 0 || i >= int64(len(c.infos)) {
+		return nil, fmt.Errorf("currency info index out of range: %d", i)
+	}
+	return &CurrencyInfo{info: c.infos[i]}, nil
+}